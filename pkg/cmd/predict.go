@@ -4,33 +4,94 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/kubecost/kubectl-cost/pkg/query"
 
 	"github.com/opencost/opencost/pkg/log"
 
+	"github.com/robfig/cron/v3"
 	"github.com/spf13/cobra"
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	yamlutil "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
+	"sigs.k8s.io/yaml"
 
 	"k8s.io/cli-runtime/pkg/genericclioptions"
 	"k8s.io/client-go/kubernetes/scheme"
 )
 
+// daysPerMonth is the assumed month length used to prorate Job/CronJob
+// costs, matching Kubecost's own "monthly" rate convention elsewhere in
+// this tool.
+const daysPerMonth = 30
+
+// labelInstanceType is the well-known node label used by most cloud
+// providers to advertise a node's instance/machine type.
+const labelInstanceType = "node.kubernetes.io/instance-type"
+
+// gpuVendor identifies the device-plugin vendor that reported a GPU
+// resource, so that mixed-vendor pods don't collapse into one untyped
+// total.
+type gpuVendor string
+
+const (
+	gpuVendorAMD    gpuVendor = "amd"
+	gpuVendorIntel  gpuVendor = "intel"
+	gpuVendorNvidia gpuVendor = "nvidia"
+)
+
 const (
-	resourceGPUKey = "gpu"
+	resourceGPUKeyAMD    = "amd.com/gpu"
+	resourceGPUKeyIntel  = "gpu.intel.com/i915"
+	resourceGPUKeyNvidia = "nvidia.com/gpu"
+
+	// Fractional/shared-GPU extended resources reported by GPU-sharing
+	// schedulers. Unlike the whole-card keys above these are requested in
+	// units of GPU memory (or, for MIG, a slice profile), and are charged
+	// as a fraction of a full GPU-hour based on the size of the card
+	// they're scheduled onto.
+	resourceGPUMemKeyAliyun    = "aliyun.com/gpu-mem"
+	resourceGPUMemKeyVolcano   = "volcano.sh/gpu-memory"
+	resourceGPUMemKeyMIGPrefix = "nvidia.com/mig-"
 )
 
+// defaultGPUMemoryBytes is the assumed memory size of a single card for a
+// vendor, used to turn a fractional-GPU memory request into a share of a
+// whole GPU-hour when the actual node's card size isn't known some other
+// way (e.g. from live node inspection). Cards of other sizes are still
+// priced, just approximately, until per-node GPU capacity is threaded
+// through from the cluster.
+var defaultGPUMemoryBytes = map[gpuVendor]int64{
+	gpuVendorNvidia: 16 * 1024 * 1024 * 1024, // e.g. V100/T4-class cards
+}
+
+// GPUShare describes a fractional GPU request made through a GPU-sharing
+// scheduler's extended resource (e.g. aliyun.com/gpu-mem), as opposed to a
+// whole-card limit.
+type GPUShare struct {
+	cardType    gpuVendor
+	memoryBytes int64
+	cores       int64
+}
+
 // PredictOptions contains options specific to prediction queries.
 type PredictOptions struct {
 	window string
@@ -42,6 +103,47 @@ type PredictOptions struct {
 
 	showCostPerResourceHr bool
 
+	// assumeNodesCount and assumeNodesInstanceType, if assumeNodesCount is
+	// non-zero, synthesize a set of nodes to match DaemonSets against, for
+	// use when no live cluster is reachable. Node-pinned
+	// Deployments/StatefulSets are priced from their own instance-type
+	// label and don't use these nodes.
+	assumeNodesCount        int
+	assumeNodesInstanceType string
+
+	// includeDaemonSets additionally scans the live cluster's DaemonSets
+	// and adds their per-replica overhead to a Deployment's prediction.
+	// StatefulSets and other kinds are not affected by it.
+	includeDaemonSets bool
+
+	// jobDuration overrides a Job's activeDeadlineSeconds when prorating
+	// its cost down from a full month to its actual expected runtime.
+	jobDuration time.Duration
+
+	// helmChart/helmValuesFiles and kustomizeDir, if set, render manifests
+	// via `helm template`/`kustomize build` instead of reading filepath
+	// directly.
+	helmChart       string
+	helmValuesFiles []string
+	kustomizeDir    string
+
+	// diff additionally fetches each workload's currently-running
+	// allocation and reports the predicted delta against it, instead of
+	// only the absolute predicted cost.
+	diff bool
+
+	// output selects the rendering of the prediction: "table" (default),
+	// "json", or "yaml". The latter two emit a stable, machine-readable
+	// schema suitable for scripting.
+	output string
+
+	// budgetMonthly and budgetDeltaPct, if non-zero, cause runCostPredict
+	// to return a non-zero exit code when the predicted monthly cost (or,
+	// in --diff mode, its percent increase over the current cost) breaches
+	// the given threshold.
+	budgetMonthly  float64
+	budgetDeltaPct float64
+
 	query.QueryBackendOptions
 }
 
@@ -72,10 +174,21 @@ func newCmdPredict(
 			return runCostPredict(kubeO, predictO)
 		},
 	}
-	cmd.Flags().StringVarP(&predictO.filepath, "filepath", "f", "", "The file containing the workload definition whose cost should be predicted. E.g. a file might be 'test-deployment.yaml' containing an apps/v1 Deployment definition. '-' can also be passed, in which case workload definitions will be read from stdin.")
+	cmd.Flags().StringVarP(&predictO.filepath, "filepath", "f", "", "The file or directory containing the workload definition(s) whose cost should be predicted. E.g. a file might be 'test-deployment.yaml' containing an apps/v1 Deployment definition. If a directory is given, every '*.yaml'/'*.yml'/'*.json' file in it is decoded. '-' can also be passed, in which case workload definitions will be read from stdin.")
 	cmd.Flags().StringVarP(&predictO.clusterID, "cluster-id", "c", "", "The cluster ID (in Kubecost) of the presumed cluster which the workload will be deployed to. This is used to determine resource costs. Defaults to all clusters.")
 	cmd.Flags().BoolVar(&predictO.showCostPerResourceHr, "show-cost-per-resource-hr", false, "Show the calculated cost per resource-hr (e.g. $/byte-hour) used for the cost prediction.")
 	cmd.Flags().StringVar(&predictO.window, "window", "2d", "The window of cost data to base resource costs on. See https://github.com/kubecost/docs/blob/master/allocation.md#querying for a detailed explanation of what can be passed here.")
+	cmd.Flags().IntVar(&predictO.assumeNodesCount, "assume-nodes", 0, "Synthesize this many nodes to evaluate DaemonSets against, instead of listing the live cluster. Useful when no cluster is reachable.")
+	cmd.Flags().StringVar(&predictO.assumeNodesInstanceType, "assume-nodes-instance-type", "", "The '"+labelInstanceType+"' label to apply to the nodes synthesized by --assume-nodes.")
+	cmd.Flags().BoolVar(&predictO.includeDaemonSets, "include-daemonsets", false, "When predicting the cost of a Deployment, also scan the cluster's DaemonSets and add their per-replica overhead, to answer \"what will N new nodes cost\" rather than just the workload's own requests.")
+	cmd.Flags().DurationVar(&predictO.jobDuration, "job-duration", 0, "How long a predicted Job is expected to run, used to prorate its cost down from a full month. Defaults to the Job's activeDeadlineSeconds, if set.")
+	cmd.Flags().StringVar(&predictO.helmChart, "helm-chart", "", "Render this Helm chart (via 'helm template') and predict the cost of every workload it contains, instead of reading --filepath.")
+	cmd.Flags().StringArrayVar(&predictO.helmValuesFiles, "helm-values", nil, "A values file to pass to 'helm template' (via --values). Can be repeated.")
+	cmd.Flags().StringVar(&predictO.kustomizeDir, "kustomize", "", "Render this kustomization directory (via 'kustomize build') and predict the cost of every workload it contains, instead of reading --filepath.")
+	cmd.Flags().BoolVar(&predictO.diff, "diff", false, "Additionally fetch each workload's currently-running allocation (matched by namespace/kind/name) and report the predicted delta against it. Workloads with no running allocation fall back to the absolute prediction.")
+	cmd.Flags().StringVarP(&predictO.output, "output", "o", "table", "Output format. One of: table|json|yaml.")
+	cmd.Flags().Float64Var(&predictO.budgetMonthly, "budget-monthly", 0, "Exit with a non-zero status if the total predicted monthly cost exceeds this amount.")
+	cmd.Flags().Float64Var(&predictO.budgetDeltaPct, "budget-delta-pct", 0, "Exit with a non-zero status if any workload's predicted cost increases by more than this percent over its current cost. Requires --diff.")
 
 	addQueryBackendOptionsFlags(cmd, &predictO.QueryBackendOptions)
 	addKubeOptionsFlags(cmd, kubeO)
@@ -86,12 +199,44 @@ func newCmdPredict(
 }
 
 func (predictO *PredictOptions) Validate() error {
-	if predictO.filepath != "-" {
+	renderedInput := predictO.helmChart != "" || predictO.kustomizeDir != ""
+
+	if !renderedInput && predictO.filepath != "-" {
 		if _, err := os.Stat(predictO.filepath); errors.Is(err, os.ErrNotExist) {
 			return fmt.Errorf("file '%s' does not exist, not a valid option", predictO.filepath)
 		}
 	}
 
+	if predictO.helmChart != "" && predictO.kustomizeDir != "" {
+		return fmt.Errorf("--helm-chart and --kustomize are mutually exclusive")
+	}
+
+	if len(predictO.helmValuesFiles) > 0 && predictO.helmChart == "" {
+		return fmt.Errorf("--helm-values requires --helm-chart")
+	}
+
+	if predictO.assumeNodesCount < 0 {
+		return fmt.Errorf("--assume-nodes must be a non-negative count, got %d", predictO.assumeNodesCount)
+	}
+
+	switch predictO.output {
+	case "table", "json", "yaml":
+	default:
+		return fmt.Errorf("--output must be one of table|json|yaml, got '%s'", predictO.output)
+	}
+
+	if predictO.budgetMonthly < 0 {
+		return fmt.Errorf("--budget-monthly must be a non-negative amount, got %f", predictO.budgetMonthly)
+	}
+
+	if predictO.budgetDeltaPct < 0 {
+		return fmt.Errorf("--budget-delta-pct must be a non-negative percentage, got %f", predictO.budgetDeltaPct)
+	}
+
+	if predictO.budgetDeltaPct > 0 && !predictO.diff {
+		return fmt.Errorf("--budget-delta-pct requires --diff")
+	}
+
 	if err := predictO.QueryBackendOptions.Validate(); err != nil {
 		return fmt.Errorf("validating query options: %s", err)
 	}
@@ -106,46 +251,404 @@ func (predictO *PredictOptions) Complete(restConfig *rest.Config) error {
 	return nil
 }
 
-func sumContainerResources(replicas int, spec v1.PodSpec) v1.ResourceList {
+// gpuShareFromLimits looks for a fractional-GPU memory request among a
+// container's limits (aliyun.com/gpu-mem, volcano.sh/gpu-memory, or an
+// nvidia.com/mig-* profile) and returns the implied GPUShare. This is only
+// consulted when the container has no whole-card limit, per invariant that
+// a whole-card limit always wins if both are present.
+func gpuShareFromLimits(limits v1.ResourceList) (GPUShare, bool) {
+	// aliyun.com/gpu-mem is requested in whole GiB of card memory.
+	// https://github.com/AliyunContainerService/gpushare-scheduler-extender
+	if qty, ok := limits[resourceGPUMemKeyAliyun]; ok {
+		return GPUShare{cardType: gpuVendorNvidia, memoryBytes: qty.Value() * 1024 * 1024 * 1024}, true
+	}
+	// volcano.sh/gpu-memory is requested in whole MiB of card memory.
+	// https://github.com/volcano-sh/devices/blob/master/docs/designs/gpu-sharing.md
+	if qty, ok := limits[resourceGPUMemKeyVolcano]; ok {
+		return GPUShare{cardType: gpuVendorNvidia, memoryBytes: qty.Value() * 1024 * 1024}, true
+	}
+	// nvidia.com/mig-<profile> requests a fixed MIG slice rather than a
+	// memory quantity, e.g. "nvidia.com/mig-1g.5gb".
+	// https://docs.nvidia.com/datacenter/tesla/mig-user-guide/
+	for name, qty := range limits {
+		if strings.HasPrefix(string(name), resourceGPUMemKeyMIGPrefix) {
+			return GPUShare{cardType: gpuVendorNvidia, cores: qty.Value()}, true
+		}
+	}
+	return GPUShare{}, false
+}
+
+// gpuQuantitiesString renders a per-vendor GPU (or GPU memory) quantity map
+// as a stable, human-readable string, e.g. "amd:1, nvidia:250m", so that
+// mixed-vendor pods don't collapse into an untyped total.
+func gpuQuantitiesString(m map[gpuVendor]resource.Quantity) string {
+	if len(m) == 0 {
+		return "0"
+	}
+
+	vendors := make([]string, 0, len(m))
+	for vendor := range m {
+		vendors = append(vendors, string(vendor))
+	}
+	sort.Strings(vendors)
+
+	parts := make([]string, 0, len(vendors))
+	for _, vendor := range vendors {
+		qty := m[gpuVendor(vendor)]
+		parts = append(parts, fmt.Sprintf("%s:%s", vendor, qty.String()))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// dominantVendor returns the vendor holding the largest quantity in m, for
+// passing through to the backend as requestedGPUType. QueryParams only
+// carries one vendor per key, so a mixed-vendor pod is approximated by its
+// largest share. ok is false if m is empty.
+func dominantVendor(m map[gpuVendor]resource.Quantity) (vendor gpuVendor, ok bool) {
+	var best *resource.Quantity
+	for v, qty := range m {
+		qty := qty
+		if best == nil || qty.Cmp(*best) > 0 {
+			vendor = v
+			best = &qty
+		}
+	}
+	return vendor, best != nil
+}
+
+// totalGPUQuantity sums a pod's per-vendor GPU quantities (whole-card counts
+// and the GPU-equivalent fraction computed for shared-GPU requests) into a
+// single vendor-agnostic total, which is the bare numeric quantity the
+// backend's requestedGPU param expects.
+func totalGPUQuantity(gpuByVendor map[gpuVendor]resource.Quantity) resource.Quantity {
+	total := resource.NewMilliQuantity(0, resource.DecimalSI)
+	for _, qty := range gpuByVendor {
+		qty := qty
+		total.Add(qty)
+	}
+	return *total
+}
+
+// schedulableNodes returns the nodes a scheduling-dependent workload should
+// be matched against: a synthesized set if --assume-nodes was given, or the
+// live cluster's nodes otherwise.
+func schedulableNodes(restConfig *rest.Config, no *PredictOptions) ([]v1.Node, error) {
+	if no.assumeNodesCount > 0 {
+		return assumedNodes(no.assumeNodesCount, no.assumeNodesInstanceType), nil
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("creating clientset: %s", err)
+	}
+	nodeList, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("listing nodes: %s", err)
+	}
+	return nodeList.Items, nil
+}
+
+// assumedNodes synthesizes a set of nodes carrying only an instance-type
+// label, for offline prediction when no live cluster is reachable.
+func assumedNodes(count int, instanceType string) []v1.Node {
+	nodes := make([]v1.Node, 0, count)
+	for i := 0; i < count; i++ {
+		node := v1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("assumed-node-%d", i),
+			},
+		}
+		if instanceType != "" {
+			node.Labels = map[string]string{labelInstanceType: instanceType}
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// matchingNodes filters nodes down to those a pod spec's nodeSelector,
+// affinity, and tolerations would actually allow it to be scheduled onto.
+func matchingNodes(nodes []v1.Node, spec v1.PodSpec) []v1.Node {
+	var matched []v1.Node
+	for _, node := range nodes {
+		if !labelsMatchSelector(spec.NodeSelector, node.Labels) {
+			continue
+		}
+		if !nodeAffinityMatches(spec.Affinity, node.Labels) {
+			continue
+		}
+		if !tolerationsMatchTaints(spec.Tolerations, node.Spec.Taints) {
+			continue
+		}
+		matched = append(matched, node)
+	}
+	return matched
+}
+
+// labelsMatchSelector reports whether every key/value pair in selector is
+// present in labels.
+func labelsMatchSelector(selector map[string]string, labels map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
+
+func nodeAffinityMatches(affinity *v1.Affinity, labels map[string]string) bool {
+	if affinity == nil || affinity.NodeAffinity == nil || affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return true
+	}
+	for _, term := range affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		if nodeSelectorTermMatches(term, labels) {
+			return true
+		}
+	}
+	return false
+}
+
+func nodeSelectorTermMatches(term v1.NodeSelectorTerm, labels map[string]string) bool {
+	// Field selectors aren't evaluated either; like an unsupported
+	// operator below, they make the term a non-match.
+	if len(term.MatchFields) > 0 {
+		return false
+	}
+	for _, expr := range term.MatchExpressions {
+		value, ok := labels[expr.Key]
+		switch expr.Operator {
+		case v1.NodeSelectorOpIn:
+			if !ok || !containsString(expr.Values, value) {
+				return false
+			}
+		case v1.NodeSelectorOpNotIn:
+			if ok && containsString(expr.Values, value) {
+				return false
+			}
+		case v1.NodeSelectorOpExists:
+			if !ok {
+				return false
+			}
+		case v1.NodeSelectorOpDoesNotExist:
+			if ok {
+				return false
+			}
+		default:
+			// Gt/Lt and field selectors aren't meaningful for the
+			// instance-type-style matching predict does; treat an
+			// unsupported operator as a non-match instead of ignoring it.
+			return false
+		}
+	}
+	return true
+}
+
+func containsString(values []string, target string) bool {
+	for _, v := range values {
+		if v == target {
+			return true
+		}
+	}
+	return false
+}
+
+func tolerationsMatchTaints(tolerations []v1.Toleration, taints []v1.Taint) bool {
+	for _, taint := range taints {
+		if taint.Effect == v1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for _, t := range tolerations {
+			if t.ToleratesTaint(&taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveInstanceType reads the node.kubernetes.io/instance-type label off
+// a pod spec's nodeSelector or required node affinity, if pinned to one, so
+// the prediction can be priced against that specific SKU rather than a
+// cluster-wide average.
+func resolveInstanceType(spec v1.PodSpec) string {
+	if v, ok := spec.NodeSelector[labelInstanceType]; ok {
+		return v
+	}
+	if spec.Affinity == nil || spec.Affinity.NodeAffinity == nil || spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, expr := range term.MatchExpressions {
+			if expr.Key == labelInstanceType && expr.Operator == v1.NodeSelectorOpIn && len(expr.Values) > 0 {
+				return expr.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// addResourceList adds src's quantities into dst in place.
+func addResourceList(dst v1.ResourceList, src v1.ResourceList) {
+	for name, qty := range src {
+		total := dst[name]
+		total.Add(qty)
+		dst[name] = total
+	}
+}
+
+// addGPUQuantities adds src's per-vendor GPU quantities into dst in place.
+func addGPUQuantities(dst map[gpuVendor]resource.Quantity, src map[gpuVendor]resource.Quantity) {
+	for vendor, qty := range src {
+		total := dst[vendor]
+		total.Add(qty)
+		dst[vendor] = total
+	}
+}
+
+// daemonSetOverhead returns the aggregate per-replica resource overhead
+// (including GPU) of live-cluster DaemonSets that would also land on the
+// nodes a workload matching spec's scheduling constraints needs, scaled by
+// replicas. This is an approximation: it assumes the workload needs one new
+// node per replica, which undercounts when replicas are bin-packed onto
+// existing nodes.
+func daemonSetOverhead(restConfig *rest.Config, spec v1.PodSpec, replicas int) (v1.ResourceList, map[gpuVendor]resource.Quantity, map[gpuVendor]resource.Quantity, error) {
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("creating clientset: %s", err)
+	}
+	dsList, err := clientset.AppsV1().DaemonSets("").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("listing daemonsets: %s", err)
+	}
+
+	perNode := v1.ResourceList{}
+	perNodeGPU := map[gpuVendor]resource.Quantity{}
+	perNodeGPUMem := map[gpuVendor]resource.Quantity{}
+	for _, ds := range dsList.Items {
+		// The new workload's nodeSelector is the closest thing we have to
+		// the label set its new nodes will carry; skip any DaemonSet whose
+		// own nodeSelector isn't satisfied by it, rather than over-counting.
+		if !labelsMatchSelector(ds.Spec.Template.Spec.NodeSelector, spec.NodeSelector) {
+			continue
+		}
+		perPod, gpuByVendor, gpuMemByVendor := sumContainerResources(1, ds.Spec.Template.Spec)
+		addResourceList(perNode, perPod)
+		addGPUQuantities(perNodeGPU, gpuByVendor)
+		addGPUQuantities(perNodeGPUMem, gpuMemByVendor)
+	}
+
+	overhead := v1.ResourceList{}
+	overheadGPU := map[gpuVendor]resource.Quantity{}
+	overheadGPUMem := map[gpuVendor]resource.Quantity{}
+	for i := 0; i < replicas; i++ {
+		addResourceList(overhead, perNode)
+		addGPUQuantities(overheadGPU, perNodeGPU)
+		addGPUQuantities(overheadGPUMem, perNodeGPUMem)
+	}
+	return overhead, overheadGPU, overheadGPUMem, nil
+}
+
+func sumContainerResources(replicas int, spec v1.PodSpec) (v1.ResourceList, map[gpuVendor]resource.Quantity, map[gpuVendor]resource.Quantity) {
 	podMemory := resource.NewQuantity(0, resource.BinarySI)
 	podCPU := resource.NewMilliQuantity(0, resource.DecimalSI)
-	podGPU := resource.NewQuantity(0, resource.DecimalSI)
+	podGPU := map[gpuVendor]*resource.Quantity{}
+	podGPUMem := map[gpuVendor]*resource.Quantity{}
+
+	addGPU := func(vendor gpuVendor, qty resource.Quantity) {
+		if podGPU[vendor] == nil {
+			podGPU[vendor] = resource.NewMilliQuantity(0, resource.DecimalSI)
+		}
+		podGPU[vendor].Add(qty)
+	}
 
 	for _, cntr := range spec.Containers {
 		requests := cntr.Resources.Requests
-		if ram, ok := requests[corev1.ResourceMemory]; ok {
+		if ram, ok := requests[v1.ResourceMemory]; ok {
 			podMemory.Add(ram)
 		}
-		if cpu, ok := requests[corev1.ResourceCPU]; ok {
+		if cpu, ok := requests[v1.ResourceCPU]; ok {
 			podCPU.Add(cpu)
 		}
 
 		// GPU is only defined in limits:
 		// https://kubernetes.io/docs/tasks/manage-gpus/scheduling-gpus/
 		limits := cntr.Resources.Limits
-		// https://github.com/RadeonOpenCompute/k8s-device-plugin/blob/master/example/pod/alexnet-gpu.yaml
-		if amdGPU, ok := limits["amd.com/gpu"]; ok {
 
-			podGPU.Add(amdGPU)
+		hasWholeCard := false
+		// https://github.com/RadeonOpenCompute/k8s-device-plugin/blob/master/example/pod/alexnet-gpu.yaml
+		if amdGPU, ok := limits[resourceGPUKeyAMD]; ok {
+			addGPU(gpuVendorAMD, amdGPU)
+			hasWholeCard = true
 		}
 		// https://github.com/intel/intel-device-plugins-for-kubernetes/blob/1380d24ee9766942f97dcce813b9868565a29218/README.md#L235
 		// https://github.com/intel/intel-device-plugins-for-kubernetes/blob/1380d24ee9766942f97dcce813b9868565a29218/demo/intelgpu-job.yaml#L22
-		if intelGPU, ok := limits["gpu.intel.com/i915"]; ok {
-			podGPU.Add(intelGPU)
+		if intelGPU, ok := limits[resourceGPUKeyIntel]; ok {
+			addGPU(gpuVendorIntel, intelGPU)
+			hasWholeCard = true
 		}
 		// https://github.com/NVIDIA/k8s-device-plugin#running-gpu-jobs
-		if nvidiaGPU, ok := limits["nvidia.com/gpu"]; ok {
-			podGPU.Add(nvidiaGPU)
+		if nvidiaGPU, ok := limits[resourceGPUKeyNvidia]; ok {
+			addGPU(gpuVendorNvidia, nvidiaGPU)
+			hasWholeCard = true
+		}
+
+		// A whole-card limit, if present, is authoritative; only fall back
+		// to fractional-GPU accounting when the container didn't ask for a
+		// whole card.
+		if hasWholeCard {
+			continue
+		}
+		share, ok := gpuShareFromLimits(limits)
+		if !ok {
+			continue
+		}
+		if share.memoryBytes > 0 {
+			if podGPUMem[share.cardType] == nil {
+				podGPUMem[share.cardType] = resource.NewQuantity(0, resource.BinarySI)
+			}
+			podGPUMem[share.cardType].Add(*resource.NewQuantity(share.memoryBytes, resource.BinarySI))
+		}
+		cardBytes := defaultGPUMemoryBytes[share.cardType]
+		switch {
+		case share.memoryBytes > 0 && cardBytes > 0:
+			fraction := float64(share.memoryBytes) / float64(cardBytes)
+			addGPU(share.cardType, *resource.NewMilliQuantity(int64(fraction*1000), resource.DecimalSI))
+		default:
+			// No known card size (or a core-based MIG profile, whose exact
+			// fraction of a physical card we don't parse yet) to divide by.
+			// Fall back to whole-card accounting rather than silently
+			// charging zero.
+			// TODO: resolve the actual card size from the node the pod
+			// lands on, and parse MIG profile names into their true slice
+			// fraction, instead of assuming one whole card.
+			addGPU(share.cardType, *resource.NewMilliQuantity(1000, resource.DecimalSI))
 		}
 	}
 
 	totalMemory := resource.NewQuantity(0, resource.BinarySI)
 	totalCPU := resource.NewMilliQuantity(0, resource.DecimalSI)
-	totalGPU := resource.NewQuantity(0, resource.DecimalSI)
+	totalGPU := map[gpuVendor]*resource.Quantity{}
+	totalGPUMem := map[gpuVendor]*resource.Quantity{}
+	for vendor := range podGPU {
+		totalGPU[vendor] = resource.NewMilliQuantity(0, resource.DecimalSI)
+	}
+	for vendor := range podGPUMem {
+		totalGPUMem[vendor] = resource.NewQuantity(0, resource.BinarySI)
+	}
 	for i := 0; i < replicas; i++ {
 		totalMemory.Add(*podMemory)
 		totalCPU.Add(*podCPU)
-		totalGPU.Add(*podGPU)
+		for vendor, qty := range podGPU {
+			totalGPU[vendor].Add(*qty)
+		}
+		for vendor, qty := range podGPUMem {
+			totalGPUMem[vendor].Add(*qty)
+		}
 	}
 
 	result := v1.ResourceList{
@@ -153,32 +656,282 @@ func sumContainerResources(replicas int, spec v1.PodSpec) v1.ResourceList {
 		v1.ResourceMemory: *totalMemory,
 	}
 
-	// Only include GPU data if we have any. Put under the generic "gpu"
-	// name because we don't (currently) distinguish between providers.
-	if !podGPU.IsZero() {
-		result[resourceGPUKey] = *podGPU
+	gpuByVendor := map[gpuVendor]resource.Quantity{}
+	for vendor, qty := range totalGPU {
+		if !qty.IsZero() {
+			gpuByVendor[vendor] = *qty
+		}
+	}
+	gpuMemByVendor := map[gpuVendor]resource.Quantity{}
+	for vendor, qty := range totalGPUMem {
+		if !qty.IsZero() {
+			gpuMemByVendor[vendor] = *qty
+		}
 	}
 
-	return result
+	return result, gpuByVendor, gpuMemByVendor
 }
 
 type predictRowData struct {
 	workloadName string
 	workloadType string
 
-	memStr string
-	cpuStr string
-	gpuStr string
+	memStr    string
+	cpuStr    string
+	gpuStr    string
+	gpuMemStr string
+
+	// hasCurrent, currentCpuStr, currentMemStr, currentMonthly, and
+	// deltaMonthly are only populated in --diff mode, and only when a
+	// currently-running allocation for the workload was found.
+	hasCurrent     bool
+	currentCpuStr  string
+	currentMemStr  string
+	currentMonthly float64
+	deltaMonthly   float64
 
 	prediction query.ResourceCostPredictionResponse
 }
 
-func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
-	var b []byte
-	var err error
-	if no.filepath == "-" {
-		reader := bufio.NewReader(ko.In)
+// summaryRow aggregates every predicted workload's monthly cost into a
+// single "TOTAL" row, so multi-workload input (a directory, a Helm chart, a
+// kustomization) reports the whole chart's monthly cost alongside the
+// per-workload breakdown.
+func summaryRow(rows []predictRowData) predictRowData {
+	var totalMonthlyCost float64
+	for _, row := range rows {
+		totalMonthlyCost += row.prediction.MonthlyCost
+	}
+	return predictRowData{
+		workloadName: "TOTAL",
+		workloadType: "Summary",
+		prediction:   query.ResourceCostPredictionResponse{MonthlyCost: totalMonthlyCost},
+	}
+}
+
+// PredictionOutput is the stable schema written by -o json|yaml. Field
+// names are part of that contract and should not be renamed without a
+// corresponding version bump to the command's documented output.
+type PredictionOutput struct {
+	CurrencyCode string                `json:"currencyCode"`
+	Rows         []PredictionRowOutput `json:"rows"`
+	Budget       *BudgetResult         `json:"budget,omitempty"`
+}
+
+// PredictionRowOutput is the machine-readable counterpart of predictRowData.
+type PredictionRowOutput struct {
+	WorkloadName string `json:"workloadName"`
+	WorkloadType string `json:"workloadType"`
+
+	CPU       string `json:"cpu"`
+	Memory    string `json:"memory"`
+	GPU       string `json:"gpu"`
+	GPUMemory string `json:"gpuMemory"`
+
+	MonthlyCost float64 `json:"monthlyCost"`
+
+	CPUHourlyRate float64 `json:"cpuHourlyRate,omitempty"`
+	RAMHourlyRate float64 `json:"ramHourlyRate,omitempty"`
+	GPUHourlyRate float64 `json:"gpuHourlyRate,omitempty"`
+
+	CurrentCPU         string  `json:"currentCpu,omitempty"`
+	CurrentMemory      string  `json:"currentMemory,omitempty"`
+	CurrentMonthlyCost float64 `json:"currentMonthlyCost,omitempty"`
+	DeltaMonthlyCost   float64 `json:"deltaMonthlyCost,omitempty"`
+}
+
+// BudgetResult reports whether --budget-monthly/--budget-delta-pct were
+// breached, and is embedded in the JSON/YAML output's "budget" object so
+// scripts don't have to re-derive it from the exit code alone.
+type BudgetResult struct {
+	MonthlyBudget    float64 `json:"monthlyBudget,omitempty"`
+	PredictedMonthly float64 `json:"predictedMonthly,omitempty"`
+
+	DeltaPctBudget float64 `json:"deltaPctBudget,omitempty"`
+
+	Breached bool   `json:"breached"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// BudgetExceededError is returned by runCostPredict when a --budget-monthly
+// or --budget-delta-pct threshold is breached, so callers (and cobra's exit
+// code) can distinguish a budget breach from a prediction failure.
+type BudgetExceededError struct {
+	Reason string
+}
+
+func (e *BudgetExceededError) Error() string {
+	return fmt.Sprintf("budget exceeded: %s", e.Reason)
+}
+
+// evaluateBudget checks the predicted rows against --budget-monthly and
+// --budget-delta-pct, returning nil if neither flag was set. The summary
+// row, if present, is excluded from the monthly total since it would
+// otherwise double-count every workload's cost.
+func evaluateBudget(no *PredictOptions, rows []predictRowData) *BudgetResult {
+	if no.budgetMonthly == 0 && no.budgetDeltaPct == 0 {
+		return nil
+	}
+
+	result := &BudgetResult{
+		MonthlyBudget:  no.budgetMonthly,
+		DeltaPctBudget: no.budgetDeltaPct,
+	}
+
+	var reasons []string
+
+	if no.budgetMonthly > 0 {
+		var totalMonthly float64
+		for _, row := range rows {
+			if row.workloadType == "Summary" {
+				continue
+			}
+			totalMonthly += row.prediction.MonthlyCost
+		}
+		result.PredictedMonthly = totalMonthly
+		if totalMonthly > no.budgetMonthly {
+			result.Breached = true
+			reasons = append(reasons, fmt.Sprintf("predicted monthly cost %.2f exceeds budget %.2f", totalMonthly, no.budgetMonthly))
+		}
+	}
 
+	if no.budgetDeltaPct > 0 {
+		for _, row := range rows {
+			if !row.hasCurrent || row.currentMonthly == 0 {
+				continue
+			}
+			deltaPct := (row.deltaMonthly / row.currentMonthly) * 100
+			if deltaPct > no.budgetDeltaPct {
+				result.Breached = true
+				reasons = append(reasons, fmt.Sprintf("%s/%s predicted cost increases %.1f%%, exceeding budget-delta-pct %.1f%%", row.workloadType, row.workloadName, deltaPct, no.budgetDeltaPct))
+			}
+		}
+	}
+
+	result.Reason = strings.Join(reasons, "; ")
+	return result
+}
+
+// writePredictionOutput renders rows as the stable JSON/YAML schema.
+func writePredictionOutput(w io.Writer, format string, rows []predictRowData, currencyCode string, budget *BudgetResult) error {
+	out := PredictionOutput{
+		CurrencyCode: currencyCode,
+		Rows:         make([]PredictionRowOutput, 0, len(rows)),
+		Budget:       budget,
+	}
+	for _, row := range rows {
+		out.Rows = append(out.Rows, PredictionRowOutput{
+			WorkloadName:       row.workloadName,
+			WorkloadType:       row.workloadType,
+			CPU:                row.cpuStr,
+			Memory:             row.memStr,
+			GPU:                row.gpuStr,
+			GPUMemory:          row.gpuMemStr,
+			MonthlyCost:        row.prediction.MonthlyCost,
+			CPUHourlyRate:      row.prediction.CPUCostPerResourceHr,
+			RAMHourlyRate:      row.prediction.RAMCostPerResourceHr,
+			GPUHourlyRate:      row.prediction.GPUCostPerResourceHr,
+			CurrentCPU:         row.currentCpuStr,
+			CurrentMemory:      row.currentMemStr,
+			CurrentMonthlyCost: row.currentMonthly,
+			DeltaMonthlyCost:   row.deltaMonthly,
+		})
+	}
+
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	case "yaml":
+		b, err := yaml.Marshal(out)
+		if err != nil {
+			return fmt.Errorf("marshaling yaml: %s", err)
+		}
+		_, err = w.Write(b)
+		return err
+	default:
+		return fmt.Errorf("unknown output format '%s'", format)
+	}
+}
+
+// writePredictionTable renders rows as the default human-readable table.
+// Diff columns (current cpu/memory, current monthly cost, delta monthly
+// cost) are only printed when at least one row has hasCurrent set, so a
+// plain (non-diff) prediction doesn't grow empty columns.
+func writePredictionTable(w io.Writer, rows []predictRowData, currencyCode string, showCostPerResourceHr bool) {
+	var showDiff bool
+	for _, row := range rows {
+		if row.hasCurrent {
+			showDiff = true
+			break
+		}
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+
+	header := []string{"WORKLOAD", "TYPE", "CPU", "MEMORY", "GPU", "GPU MEMORY"}
+	if showCostPerResourceHr {
+		header = append(header, "CPU/HR", "MEM/HR", "GPU/HR")
+	}
+	header = append(header, "MONTHLY COST")
+	if showDiff {
+		header = append(header, "CURRENT CPU", "CURRENT MEMORY", "CURRENT MONTHLY", "DELTA MONTHLY")
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	for _, row := range rows {
+		cols := []string{row.workloadName, row.workloadType, row.cpuStr, row.memStr, row.gpuStr, row.gpuMemStr}
+		if showCostPerResourceHr {
+			cols = append(cols,
+				fmt.Sprintf("%s%.6f", currencyCode, row.prediction.CPUCostPerResourceHr),
+				fmt.Sprintf("%s%.6f", currencyCode, row.prediction.RAMCostPerResourceHr),
+				fmt.Sprintf("%s%.6f", currencyCode, row.prediction.GPUCostPerResourceHr),
+			)
+		}
+		cols = append(cols, fmt.Sprintf("%s%.2f", currencyCode, row.prediction.MonthlyCost))
+		if showDiff {
+			if row.hasCurrent {
+				cols = append(cols,
+					row.currentCpuStr,
+					row.currentMemStr,
+					fmt.Sprintf("%s%.2f", currencyCode, row.currentMonthly),
+					fmt.Sprintf("%s%.2f", currencyCode, row.deltaMonthly),
+				)
+			} else {
+				cols = append(cols, "", "", "", "")
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(cols, "\t"))
+	}
+
+	tw.Flush()
+}
+
+// readManifestSources returns the raw manifest bytes predict should decode:
+// stdin, a single file, every *.yaml/*.yml/*.json file in a directory, or
+// the output of rendering a Helm chart/kustomization.
+func readManifestSources(ko *KubeOptions, no *PredictOptions) ([][]byte, error) {
+	switch {
+	case no.helmChart != "":
+		args := []string{"template", "kubectl-cost-predict", no.helmChart}
+		for _, valuesFile := range no.helmValuesFiles {
+			args = append(args, "--values", valuesFile)
+		}
+		out, err := exec.Command("helm", args...).Output()
+		if err != nil {
+			return nil, fmt.Errorf("running helm template: %s", err)
+		}
+		return [][]byte{out}, nil
+	case no.kustomizeDir != "":
+		out, err := exec.Command("kustomize", "build", no.kustomizeDir).Output()
+		if err != nil {
+			return nil, fmt.Errorf("running kustomize build: %s", err)
+		}
+		return [][]byte{out}, nil
+	case no.filepath == "-":
+		reader := bufio.NewReader(ko.In)
+		var b []byte
 		scratch := make([]byte, 1024)
 		for {
 			n, err := reader.Read(scratch)
@@ -186,30 +939,68 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 			if err == io.EOF {
 				break
 			} else if err != nil {
-				return fmt.Errorf("reading from stdin: %s", err)
+				return nil, fmt.Errorf("reading from stdin: %s", err)
 			}
 		}
-	} else {
-		b, err = ioutil.ReadFile(no.filepath)
+		return [][]byte{b}, nil
+	}
+
+	info, err := os.Stat(no.filepath)
+	if err != nil {
+		return nil, fmt.Errorf("stat '%s': %s", no.filepath, err)
+	}
+	if !info.IsDir() {
+		b, err := ioutil.ReadFile(no.filepath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read file '%s': %s", no.filepath, err)
+		}
+		return [][]byte{b}, nil
+	}
+
+	var sources [][]byte
+	err = filepath.Walk(no.filepath, func(path string, fi os.FileInfo, err error) error {
 		if err != nil {
-			return fmt.Errorf("failed to read file '%s': %s", no.filepath, err)
+			return err
 		}
+		if fi.IsDir() {
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml", ".json":
+		default:
+			return nil
+		}
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read file '%s': %s", path, err)
+		}
+		sources = append(sources, b)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("walking directory '%s': %s", no.filepath, err)
 	}
+	return sources, nil
+}
 
-	// This looping decode lets us handle multiple definitions in a single file,
-	// as usually separated with '---'
-	//
-	// https://gist.github.com/pytimer/0ad436972a073bb37b8b6b8b474520fc
+// decodeManifest decodes the K8s objects out of a single manifest source,
+// flattening any top-level v1.List.
+//
+// This looping decode lets us handle multiple definitions in a single file,
+// as usually separated with '---'
+//
+// https://gist.github.com/pytimer/0ad436972a073bb37b8b6b8b474520fc
+func decodeManifest(b []byte) ([]runtime.Object, error) {
 	decoder := yamlutil.NewYAMLOrJSONDecoder(bytes.NewReader(b), 100)
 
 	var objs []runtime.Object
 	for {
 		var rawObj runtime.RawExtension
-		if err = decoder.Decode(&rawObj); err != nil {
+		if err := decoder.Decode(&rawObj); err != nil {
 			if errors.Is(err, io.EOF) {
 				break
 			}
-			return fmt.Errorf("decoding file data as K8s object: %s", err)
+			return nil, fmt.Errorf("decoding file data as K8s object: %s", err)
 		}
 
 		// https://github.com/kubernetes/client-go/issues/193#issuecomment-343138889
@@ -236,16 +1027,150 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 		}
 		objs = append(objs, obj)
 	}
+	return objs, nil
+}
+
+// cronInvocationsPerMonth estimates how many times a standard cron schedule
+// fires in a daysPerMonth-day window, so a CronJob's per-invocation cost can
+// be scaled up to a monthly total.
+func cronInvocationsPerMonth(schedule string) (float64, error) {
+	sched, err := cron.ParseStandard(schedule)
+	if err != nil {
+		return 0, fmt.Errorf("parsing cron schedule %q: %s", schedule, err)
+	}
+
+	start := time.Now()
+	end := start.AddDate(0, 0, daysPerMonth)
+	invocations := 0
+	for next := start; ; invocations++ {
+		next = sched.Next(next)
+		// A schedule that parses but can never actually fire (e.g. "0 0 30
+		// 2 *", the 30th of February) makes Next return the zero time,
+		// which is never After(end); bail out instead of spinning forever.
+		if next.IsZero() || next.After(end) {
+			break
+		}
+	}
+	return float64(invocations), nil
+}
+
+// jobDurationSeconds returns how long a Job (or a CronJob's job template)
+// is expected to run, preferring an explicit --job-duration override over
+// activeDeadlineSeconds. A zero result means the duration is unknown, and
+// prorateMonthlyCost then assumes defaultUnknownRunSeconds per run.
+func jobDurationSeconds(no *PredictOptions, activeDeadlineSeconds *int64) float64 {
+	if no.jobDuration > 0 {
+		return no.jobDuration.Seconds()
+	}
+	if activeDeadlineSeconds != nil {
+		return float64(*activeDeadlineSeconds)
+	}
+	return 0
+}
+
+// monthSeconds is the number of seconds in daysPerMonth, used to prorate a
+// backend MonthlyCost (which assumes the requested resources ran
+// continuously for a full month) down to a Job/CronJob's actual expected
+// running time.
+const monthSeconds = float64(daysPerMonth * 24 * 60 * 60)
+
+// defaultUnknownRunSeconds is the assumed runtime of a single Job/CronJob
+// invocation when neither --job-duration nor activeDeadlineSeconds is set.
+// Kubecost's underlying cost model is driven by hourly-resolution usage
+// data, so an invocation's cost can't usefully resolve below an hour
+// anyway; defaulting to one avoids silently pricing a short-lived batch
+// workload as if it ran continuously for a full month (and, for a
+// CronJob, lets invocationsPerMonth still apply instead of being
+// discarded).
+const defaultUnknownRunSeconds = 60 * 60
+
+// prorateMonthlyCost scales monthlyCost from the backend's continuous-
+// running assumption down to durationSeconds of runtime, repeated
+// invocationsPerMonth times for a CronJob (invocationsPerMonth <= 0 is
+// treated as a single run, i.e. a plain Job). durationSeconds <= 0 falls
+// back to defaultUnknownRunSeconds rather than leaving the figure
+// unscaled, so invocationsPerMonth is never silently dropped.
+func prorateMonthlyCost(monthlyCost, durationSeconds, invocationsPerMonth float64) float64 {
+	if durationSeconds <= 0 {
+		durationSeconds = defaultUnknownRunSeconds
+	}
+	invocations := 1.0
+	if invocationsPerMonth > 0 {
+		invocations = invocationsPerMonth
+	}
+	return monthlyCost * (durationSeconds / monthSeconds) * invocations
+}
+
+// queryCurrentAllocation fetches the currently-running allocation for a
+// single namespace/kind/name workload, for --diff mode. It returns a nil
+// Aggregation (with no error) when the workload isn't currently running.
+func queryCurrentAllocation(ko *KubeOptions, no *PredictOptions, namespace, kind, name string) (*query.Aggregation, error) {
+	aggs, err := query.QueryAggCostModel(query.AggCostModelParameters{
+		RestConfig:            ko.restConfig,
+		Ctx:                   context.Background(),
+		KubecostNamespace:     no.KubecostNamespace,
+		ServiceName:           no.ServiceName,
+		UseProxy:              no.UseProxy,
+		Window:                no.window,
+		Aggregate:             "namespace,controllerKind,controller",
+		FilterClusters:        stringFilter(no.clusterID),
+		FilterNamespaces:      stringFilter(namespace),
+		// Kubecost/OpenCost store controllerKind lowercase (e.g.
+		// "deployment"), unlike the capitalized Kind on the decoded K8s
+		// object, so it has to be lowercased before filtering on it.
+		FilterControllerKinds: stringFilter(strings.ToLower(kind)),
+		FilterControllers:     stringFilter(name),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, agg := range aggs {
+		agg := agg
+		return &agg, nil
+	}
+	return nil, nil
+}
+
+// stringFilter wraps a single, possibly-empty value as the single-element
+// filter slice QueryAggCostModel expects, or nil if it's empty.
+func stringFilter(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return []string{s}
+}
+
+func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
+	sources, err := readManifestSources(ko, no)
+	if err != nil {
+		return fmt.Errorf("reading manifests: %s", err)
+	}
+
+	var objs []runtime.Object
+	for _, b := range sources {
+		decoded, err := decodeManifest(b)
+		if err != nil {
+			return err
+		}
+		objs = append(objs, decoded...)
+	}
 
 	var rowData []predictRowData
 	for _, obj := range objs {
 		var name string
 		var kind string
+		var namespace string
 
 		isStorage := false
 		var totalResources v1.ResourceList
+		var gpuByVendor map[gpuVendor]resource.Quantity
+		var gpuMemByVendor map[gpuVendor]resource.Quantity
 		var storageQty resource.Quantity
 		var storageClass string
+		var nodePool string
+		var durationSeconds float64
+		var invocationsPerMonth float64
 
 		switch typed := obj.(type) {
 		case *appsv1.Deployment:
@@ -257,7 +1182,19 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 			}
 			name = typed.Name
 			kind = "Deployment"
-			totalResources = sumContainerResources(replicas, typed.Spec.Template.Spec)
+			namespace = typed.Namespace
+			nodePool = resolveInstanceType(typed.Spec.Template.Spec)
+			totalResources, gpuByVendor, gpuMemByVendor = sumContainerResources(replicas, typed.Spec.Template.Spec)
+			if no.includeDaemonSets {
+				overhead, gpuOverhead, gpuMemOverhead, err := daemonSetOverhead(ko.restConfig, typed.Spec.Template.Spec, replicas)
+				if err != nil {
+					log.Warnf("including DaemonSet overhead for %s/%s: %s", kind, name, err)
+				} else {
+					addResourceList(totalResources, overhead)
+					addGPUQuantities(gpuByVendor, gpuOverhead)
+					addGPUQuantities(gpuMemByVendor, gpuMemOverhead)
+				}
+			}
 		case *appsv1.StatefulSet:
 			replicas := 1
 			if typed.Spec.Replicas == nil {
@@ -267,16 +1204,64 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 			}
 			name = typed.Name
 			kind = "StatefulSet"
-			totalResources = sumContainerResources(replicas, typed.Spec.Template.Spec)
+			namespace = typed.Namespace
+			nodePool = resolveInstanceType(typed.Spec.Template.Spec)
+			totalResources, gpuByVendor, gpuMemByVendor = sumContainerResources(replicas, typed.Spec.Template.Spec)
 		case *v1.Pod:
 			name = typed.Name
 			kind = "Pod"
-			totalResources = sumContainerResources(1, typed.Spec)
+			namespace = typed.Namespace
+			nodePool = resolveInstanceType(typed.Spec)
+			totalResources, gpuByVendor, gpuMemByVendor = sumContainerResources(1, typed.Spec)
 		case *appsv1.DaemonSet:
 			name = typed.Name
 			kind = "DaemonSet"
-			log.Warnf("DaemonSets are not supported because scheduling-dependent workloads are not yet supported. Skipping %s/%s.", kind, name)
-			continue
+			namespace = typed.Namespace
+			nodes, err := schedulableNodes(ko.restConfig, no)
+			if err != nil {
+				log.Warnf("listing nodes for DaemonSet %s: %s. Skipping.", name, err)
+				continue
+			}
+			matched := matchingNodes(nodes, typed.Spec.Template.Spec)
+			if len(matched) == 0 {
+				log.Warnf("no nodes match DaemonSet %s's scheduling constraints. Skipping.", name)
+				continue
+			}
+			nodePool = resolveInstanceType(typed.Spec.Template.Spec)
+			totalResources, gpuByVendor, gpuMemByVendor = sumContainerResources(len(matched), typed.Spec.Template.Spec)
+		case *batchv1.Job:
+			name = typed.Name
+			kind = "Job"
+			namespace = typed.Namespace
+			parallelism := 1
+			if typed.Spec.Parallelism != nil {
+				parallelism = int(*typed.Spec.Parallelism)
+			}
+			nodePool = resolveInstanceType(typed.Spec.Template.Spec)
+			totalResources, gpuByVendor, gpuMemByVendor = sumContainerResources(parallelism, typed.Spec.Template.Spec)
+			durationSeconds = jobDurationSeconds(no, typed.Spec.ActiveDeadlineSeconds)
+			if durationSeconds == 0 {
+				log.Warnf("no --job-duration or activeDeadlineSeconds set for Job %s; assuming it runs for %s rather than pricing it as a full month.", name, time.Duration(defaultUnknownRunSeconds)*time.Second)
+			}
+		case *batchv1.CronJob:
+			name = typed.Name
+			kind = "CronJob"
+			namespace = typed.Namespace
+			parallelism := 1
+			if typed.Spec.JobTemplate.Spec.Parallelism != nil {
+				parallelism = int(*typed.Spec.JobTemplate.Spec.Parallelism)
+			}
+			nodePool = resolveInstanceType(typed.Spec.JobTemplate.Spec.Template.Spec)
+			totalResources, gpuByVendor, gpuMemByVendor = sumContainerResources(parallelism, typed.Spec.JobTemplate.Spec.Template.Spec)
+			invocationsPerMonth, err = cronInvocationsPerMonth(typed.Spec.Schedule)
+			if err != nil {
+				log.Warnf("parsing schedule for CronJob %s: %s. Assuming a single invocation per month.", name, err)
+				invocationsPerMonth = 1
+			}
+			durationSeconds = jobDurationSeconds(no, typed.Spec.JobTemplate.Spec.ActiveDeadlineSeconds)
+			if durationSeconds == 0 {
+				log.Warnf("no --job-duration or activeDeadlineSeconds set for CronJob %s; assuming each invocation runs for %s.", name, time.Duration(defaultUnknownRunSeconds)*time.Second)
+			}
 		case *v1.PersistentVolumeClaim:
 			name = typed.Name
 			kind = "PersistentVolumeClaim"
@@ -303,7 +1288,6 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 
 		memStr := "0"
 		cpuStr := "0"
-		gpuStr := "0"
 		if mem, ok := totalResources[v1.ResourceMemory]; ok {
 			ptr := &mem
 			memStr = ptr.String()
@@ -312,18 +1296,49 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 			ptr := &cpu
 			cpuStr = ptr.String()
 		}
-		if gpu, ok := totalResources[resourceGPUKey]; ok {
-			ptr := &gpu
-			gpuStr = ptr.String()
+		gpuStr := gpuQuantitiesString(gpuByVendor)
+		gpuMemStr := gpuQuantitiesString(gpuMemByVendor)
+
+		// requestedGPU carries a bare numeric quantity, same as
+		// requestedCPU/requestedMemory; the vendor breakdown goes in
+		// requestedGPUType instead, since QueryPredictResourceCost parses
+		// requestedGPU as a plain resource.Quantity.
+		requestedGPU := totalGPUQuantity(gpuByVendor).String()
+		requestedGPUType := ""
+		requestedGPUMemory := "0"
+		// Both params have to name the same vendor: requestedGPUMemory is
+		// meaningless attributed to a different vendor than
+		// requestedGPUType. gpuMemByVendor's vendors are always a subset of
+		// gpuByVendor's (every fractional share is also folded into
+		// gpuByVendor), so picking the dominant vendor from gpuByVendor and
+		// then looking up its memory, if any, keeps the two consistent.
+		if vendor, ok := dominantVendor(gpuByVendor); ok {
+			requestedGPUType = string(vendor)
+			if memQty, ok := gpuMemByVendor[vendor]; ok {
+				requestedGPUMemory = memQty.String()
+			}
 		}
 
 		queryParams := map[string]string{
-			"window":          no.window,
-			"clusterID":       no.clusterID,
-			"requestedMemory": memStr,
-			"requestedCPU":    cpuStr,
-			"requestedGPU":    gpuStr,
+			"window":             no.window,
+			"clusterID":          no.clusterID,
+			"requestedMemory":    memStr,
+			"requestedCPU":       cpuStr,
+			"requestedGPU":       requestedGPU,
+			"requestedGPUType":   requestedGPUType,
+			"requestedGPUMemory": requestedGPUMemory,
 		}
+		if nodePool != "" {
+			// Price against the pinned instance type/node pool instead of
+			// a cluster-wide average.
+			queryParams["nodePool"] = nodePool
+			queryParams["instanceType"] = nodePool
+		}
+		// durationSeconds/invocationsPerMonth are deliberately NOT sent as
+		// query params: QueryPredictResourceCost ignores unknown params
+		// today, and prorateMonthlyCost applies them locally below. Sending
+		// both would double-prorate if the backend ever starts honoring
+		// them.
 		prediction, err := query.QueryPredictResourceCost(query.ResourcePredictParameters{
 			RestConfig:          ko.restConfig,
 			Ctx:                 context.Background(),
@@ -334,15 +1349,49 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 			return fmt.Errorf("prediction query failed: %s", err)
 		}
 
-		rowData = append(rowData, predictRowData{
+		// The backend prices requestedCPU/requestedMemory as if they ran
+		// continuously for a full month; durationSeconds/invocationsPerMonth
+		// are unknown query params to it today, so prorate locally rather
+		// than feeding a Job/CronJob's unscaled, continuous-running figure
+		// into the summary row and the --budget-monthly/--budget-delta-pct
+		// gate below.
+		prediction.MonthlyCost = prorateMonthlyCost(prediction.MonthlyCost, durationSeconds, invocationsPerMonth)
+
+		row := predictRowData{
 			workloadName: name,
 			workloadType: kind,
 			memStr:       memStr,
 			cpuStr:       cpuStr,
 			gpuStr:       gpuStr,
+			gpuMemStr:    gpuMemStr,
 			prediction:   prediction,
-		})
+		}
+		if no.diff && !isStorage {
+			if namespace == "" {
+				log.Warnf("%s/%s has no namespace set; reporting absolute prediction only.", kind, name)
+			} else if agg, err := queryCurrentAllocation(ko, no, namespace, kind, name); err != nil {
+				log.Warnf("querying current allocation for %s/%s: %s. Reporting absolute prediction only.", kind, name, err)
+			} else if agg == nil {
+				log.Debugf("no running allocation found for %s/%s; reporting absolute prediction only.", kind, name)
+			} else {
+				// prediction.MonthlyCost is compute-only (CPU/RAM/GPU), so
+				// compare it against the same slice of agg rather than
+				// TotalCost, which also bundles PV, network, and shared
+				// cost and would skew the delta.
+				currentMonthly := agg.CPUCost + agg.RAMCost + agg.GPUCost
+				row.hasCurrent = true
+				row.currentCpuStr = resource.NewMilliQuantity(int64(agg.CPUAllocationHourlyAverage*1000), resource.DecimalSI).String()
+				row.currentMemStr = resource.NewQuantity(int64(agg.RAMAllocationHourlyAverage), resource.BinarySI).String()
+				row.currentMonthly = currentMonthly
+				row.deltaMonthly = prediction.MonthlyCost - currentMonthly
+			}
+		}
+		rowData = append(rowData, row)
 	}
+	if len(rowData) > 1 {
+		rowData = append(rowData, summaryRow(rowData))
+	}
+
 	currencyCode, err := query.QueryCurrencyCode(query.CurrencyCodeParameters{
 		Ctx:                 context.Background(),
 		QueryBackendOptions: no.QueryBackendOptions,
@@ -352,6 +1401,19 @@ func runCostPredict(ko *KubeOptions, no *PredictOptions) error {
 		currencyCode = ""
 	}
 
-	writePredictionTable(ko.Out, rowData, currencyCode, no.showCostPerResourceHr)
+	budget := evaluateBudget(no, rowData)
+
+	if no.output == "json" || no.output == "yaml" {
+		if err := writePredictionOutput(ko.Out, no.output, rowData, currencyCode, budget); err != nil {
+			return fmt.Errorf("writing %s output: %s", no.output, err)
+		}
+	} else {
+		writePredictionTable(ko.Out, rowData, currencyCode, no.showCostPerResourceHr)
+	}
+
+	if budget != nil && budget.Breached {
+		return &BudgetExceededError{Reason: budget.Reason}
+	}
+
 	return nil
 }
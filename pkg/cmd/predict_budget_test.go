@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/kubecost/kubectl-cost/pkg/query"
+)
+
+// TestEvaluateBudget_NoFlagsSet pins that evaluateBudget is a no-op when
+// neither --budget-monthly nor --budget-delta-pct is set.
+func TestEvaluateBudget_NoFlagsSet(t *testing.T) {
+	no := &PredictOptions{}
+	if got := evaluateBudget(no, nil); got != nil {
+		t.Fatalf("expected nil, got %v", got)
+	}
+}
+
+// TestEvaluateBudget_MonthlyBreach pins that the summary row is excluded
+// from the monthly total, since it would otherwise double-count every
+// workload's cost.
+func TestEvaluateBudget_MonthlyBreach(t *testing.T) {
+	no := &PredictOptions{budgetMonthly: 100}
+	rows := []predictRowData{
+		{workloadType: "Deployment", prediction: query.ResourceCostPredictionResponse{MonthlyCost: 60}},
+		{workloadType: "Deployment", prediction: query.ResourceCostPredictionResponse{MonthlyCost: 60}},
+		{workloadType: "Summary", prediction: query.ResourceCostPredictionResponse{MonthlyCost: 120}},
+	}
+
+	got := evaluateBudget(no, rows)
+	if got == nil || !got.Breached {
+		t.Fatalf("expected a breach, got %v", got)
+	}
+	if got.PredictedMonthly != 120 {
+		t.Fatalf("expected the summary row excluded from the total (120), got %v", got.PredictedMonthly)
+	}
+}
+
+// TestEvaluateBudget_DeltaPctBreach pins that a workload's percent cost
+// increase over its current cost, not its absolute delta, drives
+// --budget-delta-pct.
+func TestEvaluateBudget_DeltaPctBreach(t *testing.T) {
+	no := &PredictOptions{budgetDeltaPct: 50}
+	rows := []predictRowData{
+		{workloadType: "Deployment", workloadName: "web", hasCurrent: true, currentMonthly: 10, deltaMonthly: 6},
+	}
+
+	got := evaluateBudget(no, rows)
+	if got == nil || !got.Breached {
+		t.Fatalf("expected a breach, got %v", got)
+	}
+}
+
+// TestEvaluateBudget_DeltaPctIgnoresRowsWithoutCurrent pins that rows with
+// no current allocation (hasCurrent false) or a zero current cost are
+// skipped rather than dividing by zero.
+func TestEvaluateBudget_DeltaPctIgnoresRowsWithoutCurrent(t *testing.T) {
+	no := &PredictOptions{budgetDeltaPct: 1}
+	rows := []predictRowData{
+		{workloadType: "Deployment", workloadName: "no-current", hasCurrent: false, deltaMonthly: 1000},
+		{workloadType: "Deployment", workloadName: "zero-current", hasCurrent: true, currentMonthly: 0, deltaMonthly: 1000},
+	}
+
+	got := evaluateBudget(no, rows)
+	if got == nil {
+		t.Fatal("expected a non-nil result since budgetDeltaPct was set")
+	}
+	if got.Breached {
+		t.Fatalf("expected no breach, got %v", got)
+	}
+}
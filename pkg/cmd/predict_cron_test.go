@@ -0,0 +1,70 @@
+package cmd
+
+import "testing"
+
+// TestCronInvocationsPerMonth_Typical pins that a schedule which fires every
+// minute yields roughly daysPerMonth*24*60 invocations.
+func TestCronInvocationsPerMonth_Typical(t *testing.T) {
+	got, err := cronInvocationsPerMonth("* * * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := float64(daysPerMonth * 24 * 60)
+	if got != want {
+		t.Fatalf("expected %v invocations, got %v", want, got)
+	}
+}
+
+// TestCronInvocationsPerMonth_NeverFires pins the invariant that a schedule
+// which parses but can never actually fire (the 30th of February) returns
+// promptly instead of spinning forever in sched.Next.
+func TestCronInvocationsPerMonth_NeverFires(t *testing.T) {
+	got, err := cronInvocationsPerMonth("0 0 30 2 *")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got != 0 {
+		t.Fatalf("expected 0 invocations for an unreachable schedule, got %v", got)
+	}
+}
+
+// TestCronInvocationsPerMonth_InvalidSchedule pins that a malformed schedule
+// is reported as an error rather than silently returning 0.
+func TestCronInvocationsPerMonth_InvalidSchedule(t *testing.T) {
+	if _, err := cronInvocationsPerMonth("not a schedule"); err == nil {
+		t.Fatal("expected an error for an invalid cron schedule")
+	}
+}
+
+// TestProrateMonthlyCost_KnownDuration pins the scaling of a continuous-
+// running monthly cost down to durationSeconds, repeated invocationsPerMonth
+// times.
+func TestProrateMonthlyCost_KnownDuration(t *testing.T) {
+	got := prorateMonthlyCost(monthSeconds, 3600, 30)
+	want := 3600.0 / monthSeconds * monthSeconds * 30
+	if got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// TestProrateMonthlyCost_SingleRun pins that invocationsPerMonth<=0 is
+// treated as a single run (a plain Job), not zero.
+func TestProrateMonthlyCost_SingleRun(t *testing.T) {
+	got := prorateMonthlyCost(monthSeconds, 3600, 0)
+	want := 3600.0 / monthSeconds * monthSeconds
+	if got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// TestProrateMonthlyCost_UnknownDuration pins the fallback to
+// defaultUnknownRunSeconds when durationSeconds is unknown, so
+// invocationsPerMonth is never silently dropped for a CronJob with no
+// activeDeadlineSeconds.
+func TestProrateMonthlyCost_UnknownDuration(t *testing.T) {
+	got := prorateMonthlyCost(monthSeconds, 0, 30)
+	want := float64(defaultUnknownRunSeconds) / monthSeconds * monthSeconds * 30
+	if got != want {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
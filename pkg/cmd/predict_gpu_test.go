@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func containerWithLimits(limits v1.ResourceList) v1.Container {
+	return v1.Container{
+		Resources: v1.ResourceRequirements{
+			Limits: limits,
+		},
+	}
+}
+
+// TestSumContainerResources_WholeCardWins pins the invariant that a
+// whole-card GPU limit is authoritative even when a fractional-GPU limit is
+// also present on the same container.
+func TestSumContainerResources_WholeCardWins(t *testing.T) {
+	spec := v1.PodSpec{
+		Containers: []v1.Container{
+			containerWithLimits(v1.ResourceList{
+				v1.ResourceName(resourceGPUKeyNvidia):    resource.MustParse("1"),
+				v1.ResourceName(resourceGPUMemKeyAliyun): resource.MustParse("8"),
+			}),
+		},
+	}
+
+	_, gpuByVendor, gpuMemByVendor := sumContainerResources(1, spec)
+
+	qty, ok := gpuByVendor[gpuVendorNvidia]
+	if !ok || qty.MilliValue() != 1000 {
+		t.Fatalf("expected whole-card nvidia GPU of 1, got %v (present: %v)", qty, ok)
+	}
+	if len(gpuMemByVendor) != 0 {
+		t.Fatalf("expected no fractional-GPU memory accounted when a whole card is present, got %v", gpuMemByVendor)
+	}
+}
+
+// TestSumContainerResources_FractionalGPUMemSumsAsBinarySI pins the
+// invariant that fractional-GPU memory requests across multiple containers
+// are summed as quantities (BinarySI), not concatenated strings.
+func TestSumContainerResources_FractionalGPUMemSumsAsBinarySI(t *testing.T) {
+	spec := v1.PodSpec{
+		Containers: []v1.Container{
+			containerWithLimits(v1.ResourceList{
+				v1.ResourceName(resourceGPUMemKeyAliyun): resource.MustParse("8"),
+			}),
+			containerWithLimits(v1.ResourceList{
+				v1.ResourceName(resourceGPUMemKeyAliyun): resource.MustParse("8"),
+			}),
+		},
+	}
+
+	_, _, gpuMemByVendor := sumContainerResources(1, spec)
+
+	qty, ok := gpuMemByVendor[gpuVendorNvidia]
+	if !ok {
+		t.Fatalf("expected nvidia fractional-GPU memory to be accounted")
+	}
+	wantBytes := int64(16) * 1024 * 1024 * 1024
+	if qty.Value() != wantBytes {
+		t.Fatalf("expected summed GPU memory of %d bytes, got %d", wantBytes, qty.Value())
+	}
+}
+
+// TestSumContainerResources_FallbackNotZero pins the invariant that a
+// fractional-GPU request we can't size against a known card (e.g. a MIG
+// core-based profile) still charges a whole GPU-hour rather than zero.
+func TestSumContainerResources_FallbackNotZero(t *testing.T) {
+	spec := v1.PodSpec{
+		Containers: []v1.Container{
+			containerWithLimits(v1.ResourceList{
+				v1.ResourceName(resourceGPUMemKeyMIGPrefix + "1g.5gb"): resource.MustParse("1"),
+			}),
+		},
+	}
+
+	_, gpuByVendor, _ := sumContainerResources(1, spec)
+
+	qty, ok := gpuByVendor[gpuVendorNvidia]
+	if !ok || qty.IsZero() {
+		t.Fatalf("expected a non-zero fallback GPU share, got %v (present: %v)", qty, ok)
+	}
+}
+
+// TestTotalGPUQuantity pins the invariant that requestedGPU is a bare
+// vendor-agnostic numeric total, summed across vendors.
+func TestTotalGPUQuantity(t *testing.T) {
+	m := map[gpuVendor]resource.Quantity{
+		gpuVendorAMD:    resource.MustParse("1"),
+		gpuVendorNvidia: resource.MustParse("2"),
+	}
+
+	total := totalGPUQuantity(m)
+	if total.MilliValue() != 3000 {
+		t.Fatalf("expected total GPU quantity of 3, got %s", total.String())
+	}
+}
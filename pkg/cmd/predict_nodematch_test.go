@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"testing"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// TestMatchingNodes_NodeSelectorAndTaints pins that a node is only matched
+// when its labels satisfy the pod's nodeSelector AND its taints are
+// tolerated.
+func TestMatchingNodes_NodeSelectorAndTaints(t *testing.T) {
+	spec := v1.PodSpec{
+		NodeSelector: map[string]string{"pool": "gpu"},
+		Tolerations: []v1.Toleration{
+			{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule},
+		},
+	}
+	nodes := []v1.Node{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "match"},
+			Spec: v1.NodeSpec{
+				Taints: []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}},
+			},
+			Status: v1.NodeStatus{},
+		},
+	}
+	nodes[0].Labels = map[string]string{"pool": "gpu"}
+
+	wrongLabel := v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "wrong-label", Labels: map[string]string{"pool": "cpu"}}}
+	untolerated := v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "untolerated", Labels: map[string]string{"pool": "gpu"}},
+		Spec: v1.NodeSpec{
+			Taints: []v1.Taint{{Key: "dedicated", Value: "other", Effect: v1.TaintEffectNoSchedule}},
+		},
+	}
+
+	matched := matchingNodes(append(nodes, wrongLabel, untolerated), spec)
+	if len(matched) != 1 || matched[0].Name != "match" {
+		t.Fatalf("expected only the matching node, got %v", matched)
+	}
+}
+
+// TestNodeSelectorTermMatches_Operators pins the behavior of each supported
+// NodeSelectorTerm operator.
+func TestNodeSelectorTermMatches_Operators(t *testing.T) {
+	labels := map[string]string{"zone": "us-east-1a"}
+
+	cases := []struct {
+		name string
+		term v1.NodeSelectorTerm
+		want bool
+	}{
+		{
+			name: "In matches",
+			term: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"us-east-1a"}},
+			}},
+			want: true,
+		},
+		{
+			name: "In no match",
+			term: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "zone", Operator: v1.NodeSelectorOpIn, Values: []string{"us-east-1b"}},
+			}},
+			want: false,
+		},
+		{
+			name: "NotIn excludes",
+			term: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "zone", Operator: v1.NodeSelectorOpNotIn, Values: []string{"us-east-1a"}},
+			}},
+			want: false,
+		},
+		{
+			name: "Exists",
+			term: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "zone", Operator: v1.NodeSelectorOpExists},
+			}},
+			want: true,
+		},
+		{
+			name: "DoesNotExist",
+			term: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "missing", Operator: v1.NodeSelectorOpDoesNotExist},
+			}},
+			want: true,
+		},
+		{
+			name: "unsupported operator is a non-match",
+			term: v1.NodeSelectorTerm{MatchExpressions: []v1.NodeSelectorRequirement{
+				{Key: "zone", Operator: v1.NodeSelectorOpGt, Values: []string{"0"}},
+			}},
+			want: false,
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := nodeSelectorTermMatches(c.term, labels); got != c.want {
+				t.Fatalf("expected %v, got %v", c.want, got)
+			}
+		})
+	}
+}
+
+// TestTolerationsMatchTaints_PreferNoScheduleIgnored pins that a
+// PreferNoSchedule taint doesn't require a matching toleration, since it's
+// advisory rather than a hard scheduling constraint.
+func TestTolerationsMatchTaints_PreferNoScheduleIgnored(t *testing.T) {
+	taints := []v1.Taint{{Key: "spot", Value: "true", Effect: v1.TaintEffectPreferNoSchedule}}
+	if !tolerationsMatchTaints(nil, taints) {
+		t.Fatal("expected a PreferNoSchedule taint to not require a toleration")
+	}
+}
+
+// TestTolerationsMatchTaints_NoScheduleRequiresToleration pins that a
+// NoSchedule taint without a matching toleration excludes the node.
+func TestTolerationsMatchTaints_NoScheduleRequiresToleration(t *testing.T) {
+	taints := []v1.Taint{{Key: "dedicated", Value: "gpu", Effect: v1.TaintEffectNoSchedule}}
+	if tolerationsMatchTaints(nil, taints) {
+		t.Fatal("expected a NoSchedule taint with no tolerations to exclude the node")
+	}
+	tolerations := []v1.Toleration{{Key: "dedicated", Operator: v1.TolerationOpEqual, Value: "gpu", Effect: v1.TaintEffectNoSchedule}}
+	if !tolerationsMatchTaints(tolerations, taints) {
+		t.Fatal("expected a matching toleration to satisfy the taint")
+	}
+}
+
+// TestResolveInstanceType_NodeSelector pins that an instance type pinned via
+// nodeSelector is resolved.
+func TestResolveInstanceType_NodeSelector(t *testing.T) {
+	spec := v1.PodSpec{NodeSelector: map[string]string{labelInstanceType: "m5.large"}}
+	if got := resolveInstanceType(spec); got != "m5.large" {
+		t.Fatalf("expected m5.large, got %q", got)
+	}
+}
+
+// TestResolveInstanceType_NodeAffinity pins that an instance type pinned via
+// required node affinity is resolved when no nodeSelector is set.
+func TestResolveInstanceType_NodeAffinity(t *testing.T) {
+	spec := v1.PodSpec{
+		Affinity: &v1.Affinity{
+			NodeAffinity: &v1.NodeAffinity{
+				RequiredDuringSchedulingIgnoredDuringExecution: &v1.NodeSelector{
+					NodeSelectorTerms: []v1.NodeSelectorTerm{
+						{MatchExpressions: []v1.NodeSelectorRequirement{
+							{Key: labelInstanceType, Operator: v1.NodeSelectorOpIn, Values: []string{"p3.2xlarge"}},
+						}},
+					},
+				},
+			},
+		},
+	}
+	if got := resolveInstanceType(spec); got != "p3.2xlarge" {
+		t.Fatalf("expected p3.2xlarge, got %q", got)
+	}
+}
+
+// TestResolveInstanceType_Unpinned pins that an unpinned pod spec resolves
+// to an empty string rather than a zero-value placeholder.
+func TestResolveInstanceType_Unpinned(t *testing.T) {
+	if got := resolveInstanceType(v1.PodSpec{}); got != "" {
+		t.Fatalf("expected empty string, got %q", got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -29,6 +30,14 @@ type AggCostModelParameters struct {
 	Aggregate           string
 	AggregationSubfield string
 	UseProxy            bool
+
+	// Filter* narrow the aggregation down to specific clusters, namespaces,
+	// controller kinds, or controller names, e.g. to fetch the current
+	// allocation of one workload predict is comparing a prediction against.
+	FilterClusters        []string
+	FilterNamespaces      []string
+	FilterControllerKinds []string
+	FilterControllers     []string
 }
 
 // QueryAggCostModel queries /model/aggregatedCostModel by proxying a request to Kubecost
@@ -45,6 +54,18 @@ func QueryAggCostModel(p AggCostModelParameters) (map[string]Aggregation, error)
 	if p.AggregationSubfield != "" {
 		requestParams["aggregationSubfield"] = p.AggregationSubfield
 	}
+	if len(p.FilterClusters) > 0 {
+		requestParams["filterClusters"] = strings.Join(p.FilterClusters, ",")
+	}
+	if len(p.FilterNamespaces) > 0 {
+		requestParams["filterNamespaces"] = strings.Join(p.FilterNamespaces, ",")
+	}
+	if len(p.FilterControllerKinds) > 0 {
+		requestParams["filterControllerKinds"] = strings.Join(p.FilterControllerKinds, ",")
+	}
+	if len(p.FilterControllers) > 0 {
+		requestParams["filterControllers"] = strings.Join(p.FilterControllers, ",")
+	}
 
 	var bytes []byte
 	var err error